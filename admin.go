@@ -0,0 +1,67 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// adminToken gates /admin/cache. When set, requests must carry a matching
+// X-Admin-Token header; when unset, the endpoint only accepts requests
+// from loopback, which is a reasonable default for a single-box deploy
+// but should not be relied on behind any shared/multi-tenant network.
+var adminToken = envString("LIBSEARCH_ADMIN_TOKEN", "")
+
+// adminCache handles GET /admin/cache (inspect current entries and their
+// expiry) and DELETE /admin/cache (evict a single entry via ?key=..., or
+// clear the whole cache with no key).
+func adminCache(w http.ResponseWriter, r *http.Request) {
+	if !authorizedAdmin(r) {
+		log.WithFields(log.Fields{"remote": r.RemoteAddr}).Warn("Rejected unauthorized /admin/cache request")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(cache.snapshot())
+
+	case http.MethodDelete:
+		if key := r.URL.Query().Get("key"); key != "" {
+			if !cache.evict(key) {
+				w.WriteHeader(http.StatusNotFound)
+			}
+			return
+		}
+		cache.clear()
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// authorizedAdmin reports whether r may use the /admin endpoints.
+func authorizedAdmin(r *http.Request) bool {
+	if adminToken != "" {
+		supplied := r.Header.Get("X-Admin-Token")
+		return subtle.ConstantTimeCompare([]byte(supplied), []byte(adminToken)) == 1
+	}
+
+	return isLoopback(r.RemoteAddr)
+}
+
+// isLoopback reports whether addr (an http.Request.RemoteAddr) is a
+// loopback address.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsLoopback(t *testing.T) {
+	cases := []struct {
+		addr string
+		want bool
+	}{
+		{"127.0.0.1:54321", true},
+		{"[::1]:54321", true},
+		{"10.0.0.5:54321", false},
+		{"not-an-ip:54321", false},
+	}
+
+	for _, c := range cases {
+		if got := isLoopback(c.addr); got != c.want {
+			t.Errorf("isLoopback(%q) = %v, want %v", c.addr, got, c.want)
+		}
+	}
+}
+
+func TestAuthorizedAdminWithToken(t *testing.T) {
+	oldToken := adminToken
+	adminToken = "secret"
+	defer func() { adminToken = oldToken }()
+
+	req := httptest.NewRequest("GET", "/admin/cache", nil)
+	req.RemoteAddr = "10.0.0.5:1234"
+	req.Header.Set("X-Admin-Token", "secret")
+	if !authorizedAdmin(req) {
+		t.Error("authorizedAdmin with correct token = false, want true")
+	}
+
+	req.Header.Set("X-Admin-Token", "wrong")
+	if authorizedAdmin(req) {
+		t.Error("authorizedAdmin with incorrect token = true, want false")
+	}
+}
+
+func TestAuthorizedAdminWithoutTokenRequiresLoopback(t *testing.T) {
+	oldToken := adminToken
+	adminToken = ""
+	defer func() { adminToken = oldToken }()
+
+	req := httptest.NewRequest("GET", "/admin/cache", nil)
+	req.RemoteAddr = "127.0.0.1:1234"
+	if !authorizedAdmin(req) {
+		t.Error("authorizedAdmin from loopback with no token configured = false, want true")
+	}
+
+	req.RemoteAddr = "203.0.113.5:1234"
+	if authorizedAdmin(req) {
+		t.Error("authorizedAdmin from non-loopback with no token configured = true, want false")
+	}
+}
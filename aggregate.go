@@ -0,0 +1,173 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// aggregateBooks merges bookHits that refer to the same work across
+// libraries into canonical Books, then ranks them by aggregate
+// availability so the best chance of borrowing soon sorts first.
+func aggregateBooks(hits []bookHit) []Book {
+	var books []Book
+
+	for _, hit := range hits {
+		if idx := matchBook(books, hit); idx >= 0 {
+			mergeHit(&books[idx], hit)
+			continue
+		}
+		books = append(books, newBook(hit))
+	}
+
+	sort.SliceStable(books, func(i, j int) bool {
+		return availabilityScore(books[i]) > availabilityScore(books[j])
+	})
+
+	return books
+}
+
+// matchBook finds an existing Book that hit almost certainly refers to
+// the same work. An ISBN match is authoritative; failing that, fall back
+// to a fuzzy title match corroborated by either a matching author or a
+// matching cover image (a cheap stand-in for a real perceptual cover
+// hash, which would need fetching and hashing the image).
+func matchBook(books []Book, hit bookHit) int {
+	for i, book := range books {
+		if hit.ISBN != "" && contains(book.ISBNs, hit.ISBN) {
+			return i
+		}
+
+		if !fuzzyTitleMatch(book.Title, hit.Title) {
+			continue
+		}
+
+		if hit.Author == "" || contains(book.Authors, hit.Author) {
+			return i
+		}
+
+		if hit.CoverURL != "" && hit.CoverURL == book.CoverURL {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func newBook(hit bookHit) Book {
+	var book Book
+	mergeHit(&book, hit)
+	return book
+}
+
+func mergeHit(book *Book, hit bookHit) {
+	if book.Title == "" {
+		book.Title = hit.Title
+	}
+	if book.CoverURL == "" {
+		book.CoverURL = hit.CoverURL
+	}
+	if hit.Author != "" && !contains(book.Authors, hit.Author) {
+		book.Authors = append(book.Authors, hit.Author)
+	}
+	if hit.ISBN != "" && !contains(book.ISBNs, hit.ISBN) {
+		book.ISBNs = append(book.ISBNs, hit.ISBN)
+	}
+	if hit.Format != "" && !contains(book.Formats, hit.Format) {
+		book.Formats = append(book.Formats, hit.Format)
+	}
+	book.Sources = append(book.Sources, hit.LibraryAvailability)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// fuzzyTitleMatch compares titles tolerantly enough to catch the same
+// edition listed slightly differently across library catalogs - e.g. "The
+// Great Gatsby" vs "The Great Gatsby: A Novel". It folds case/whitespace,
+// then accepts an exact match, one title's words being a leading subsequence
+// of the other's (covers an appended subtitle), or a high token overlap
+// (covers reordered or lightly reworded titles).
+func fuzzyTitleMatch(a, b string) bool {
+	na, nb := normalizeQuery(a), normalizeQuery(b)
+	if na == "" || nb == "" {
+		return false
+	}
+	if na == nb {
+		return true
+	}
+	if tokenPrefixMatch(na, nb) || tokenPrefixMatch(nb, na) {
+		return true
+	}
+	return titleTokenOverlap(na, nb) >= 0.8
+}
+
+// tokenPrefixMatch reports whether short's words are a leading, whole-word
+// run of long's words - i.e. short is long with a subtitle chopped off. A
+// raw string prefix isn't enough: it would also match "It" against
+// "Italian Cooking", since both share a leading character run. A single
+// shared word isn't enough either - "A" is a leading word of practically
+// every title starting with an article - so short must contribute at
+// least two words before it counts as a meaningful match.
+func tokenPrefixMatch(short, long string) bool {
+	shortTokens := strings.Fields(short)
+	longTokens := strings.Fields(long)
+	if len(shortTokens) < 2 || len(shortTokens) >= len(longTokens) {
+		return false
+	}
+	for i, tok := range shortTokens {
+		if strings.TrimRight(longTokens[i], ":;,.!?") != tok {
+			return false
+		}
+	}
+	return true
+}
+
+// titleTokenOverlap returns the fraction of the smaller title's words
+// that also appear in the other, as a loose similarity score. Both
+// arguments are expected to already be normalized (lowercased,
+// whitespace-folded).
+func titleTokenOverlap(a, b string) float64 {
+	tokensA := strings.Fields(a)
+	tokensB := strings.Fields(b)
+	if len(tokensA) < 2 || len(tokensB) < 2 {
+		// A single shared word is too common (articles, "the") to mean
+		// anything on its own, so a one-word title can't match by overlap.
+		return 0
+	}
+
+	setA := make(map[string]bool, len(tokensA))
+	for _, tok := range tokensA {
+		setA[tok] = true
+	}
+
+	matches := 0
+	for _, tok := range tokensB {
+		if setA[tok] {
+			matches++
+		}
+	}
+
+	smaller := len(tokensA)
+	if len(tokensB) < smaller {
+		smaller = len(tokensB)
+	}
+
+	return float64(matches) / float64(smaller)
+}
+
+// availabilityScore ranks Books by how likely a reader is to get a copy
+// soon: more available copies relative to holds ranks higher.
+func availabilityScore(book Book) float64 {
+	var available, holds float64
+	for _, src := range book.Sources {
+		available += float64(src.CopiesAvailable)
+		holds += float64(src.Holds)
+	}
+	return available / (holds + 1)
+}
@@ -0,0 +1,80 @@
+package main
+
+import "testing"
+
+func TestAggregateBooksMergesBySharedISBN(t *testing.T) {
+	hits := []bookHit{
+		{Title: "Dune", Author: "Frank Herbert", ISBN: "123",
+			LibraryAvailability: LibraryAvailability{Backend: "Overdrive", Library: "lapl", CopiesAvailable: 1}},
+		{Title: "Dune (Unabridged)", Author: "Frank Herbert", ISBN: "123",
+			LibraryAvailability: LibraryAvailability{Backend: "Cloud Library", Library: "melbourne", CopiesAvailable: 2}},
+	}
+
+	books := aggregateBooks(hits)
+	if len(books) != 1 {
+		t.Fatalf("got %d books, want 1 merged book", len(books))
+	}
+	if len(books[0].Sources) != 2 {
+		t.Errorf("got %d sources, want 2", len(books[0].Sources))
+	}
+}
+
+func TestAggregateBooksMergesFuzzyTitleMatch(t *testing.T) {
+	hits := []bookHit{
+		{Title: "The Great Gatsby", Author: "F. Scott Fitzgerald",
+			LibraryAvailability: LibraryAvailability{Backend: "Overdrive", Library: "lapl"}},
+		{Title: "The Great Gatsby: A Novel", Author: "F. Scott Fitzgerald",
+			LibraryAvailability: LibraryAvailability{Backend: "Cloud Library", Library: "melbourne"}},
+	}
+
+	books := aggregateBooks(hits)
+	if len(books) != 1 {
+		t.Fatalf("got %d books, want 1 merged book (fuzzy title match on subtitle variance)", len(books))
+	}
+}
+
+func TestAggregateBooksKeepsDistinctWorksSeparate(t *testing.T) {
+	hits := []bookHit{
+		{Title: "Dune", Author: "Frank Herbert",
+			LibraryAvailability: LibraryAvailability{Backend: "Overdrive", Library: "lapl"}},
+		{Title: "Educated", Author: "Tara Westover",
+			LibraryAvailability: LibraryAvailability{Backend: "Overdrive", Library: "erl"}},
+	}
+
+	books := aggregateBooks(hits)
+	if len(books) != 2 {
+		t.Fatalf("got %d books, want 2 distinct books", len(books))
+	}
+}
+
+func TestAggregateBooksRanksByAvailability(t *testing.T) {
+	hits := []bookHit{
+		{Title: "Low Availability", LibraryAvailability: LibraryAvailability{CopiesAvailable: 0, Holds: 10}},
+		{Title: "High Availability", LibraryAvailability: LibraryAvailability{CopiesAvailable: 5, Holds: 0}},
+	}
+
+	books := aggregateBooks(hits)
+	if books[0].Title != "High Availability" {
+		t.Errorf("books[0].Title = %q, want %q (ranked first by availability)", books[0].Title, "High Availability")
+	}
+}
+
+func TestFuzzyTitleMatch(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"The Great Gatsby", "the   great gatsby", true},
+		{"The Great Gatsby", "The Great Gatsby: A Novel", true},
+		{"Dune", "Educated", false},
+		{"", "Dune", false},
+		{"It", "Italian Cooking", false},
+		{"A", "A Tale of Two Cities", false},
+	}
+
+	for _, c := range cases {
+		if got := fuzzyTitleMatch(c.a, c.b); got != c.want {
+			t.Errorf("fuzzyTitleMatch(%q, %q) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
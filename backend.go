@@ -0,0 +1,46 @@
+package main
+
+import "context"
+
+// Result is a single search hit returned by a Backend, before any
+// per-service normalization is applied by the caller.
+type Result struct {
+	Library string
+	Data    interface{}
+}
+
+// Backend is implemented by each library service integration (Overdrive,
+// CloudLibrary, a headless-browser scraper, etc). search fans a query out
+// across every registered Backend's domains concurrently.
+type Backend interface {
+	// Name is the human-readable service name, e.g. "Overdrive".
+	Name() string
+	// Domains lists the library tenants/subdomains this backend knows how
+	// to query.
+	Domains() []string
+	// Search queries a single domain for query and returns the raw
+	// results for the given page (1-indexed), or a nil Result if the
+	// domain had no hits. Backends translate page into whatever
+	// pagination scheme their upstream API uses.
+	Search(ctx context.Context, query string, domain string, page int) (*Result, error)
+}
+
+// registry holds every Backend registered via RegisterBackend, keyed by
+// its Name().
+var registry = map[string]Backend{}
+
+// RegisterBackend adds a Backend to the global registry. Backends call
+// this from an init() function so that adding a new library service is a
+// single new file.
+func RegisterBackend(b Backend) {
+	registry[b.Name()] = b
+}
+
+// Backends returns every registered Backend.
+func Backends() []Backend {
+	out := make([]Backend, 0, len(registry))
+	for _, b := range registry {
+		out = append(out, b)
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/chromedp/chromedp"
+)
+
+func init() {
+	RegisterBackend(&BrowserBackend{
+		BackendName: "BiblioCommons",
+		// BiblioCommons-hosted catalogs: their search results are
+		// rendered client-side by a React bundle, so a plain HTTP GET
+		// (as OverdriveBackend/CloudLibraryBackend do) only ever sees an
+		// empty shell - this is the JS-rendered case BrowserBackend
+		// exists for.
+		BackendDomains: []string{"sfpl", "bklynlibrary"},
+		URLTemplate:    "https://%s.bibliocommons.com/v2/search",
+		WaitSelector:   ".cp-search-result-item",
+		Extract:        extractBiblioCommonsResults,
+	})
+}
+
+// biblioCommonsExtractJS scrapes the rendered result list into the same
+// {Title, Author, CoverUrl, AvailableCopies} shape CloudLibraryBackend's
+// JSON response already uses, so both can be normalized the same way.
+const biblioCommonsExtractJS = `
+JSON.stringify(Array.from(document.querySelectorAll('.cp-search-result-item')).map(function(el) {
+	var title = el.querySelector('.title-content');
+	var author = el.querySelector('.author-link');
+	var cover = el.querySelector('.jacket-cover img');
+	return {
+		Title: title ? title.textContent.trim() : '',
+		Author: author ? author.textContent.trim() : '',
+		CoverUrl: cover ? cover.getAttribute('src') : '',
+		AvailableCopies: el.querySelector('.cp-availability-status.available') ? 1 : 0
+	};
+}))
+`
+
+// extractBiblioCommonsResults runs biblioCommonsExtractJS against the
+// already-loaded search results page and decodes it into the
+// {"Items": [...]} envelope shared with CloudLibraryBackend.
+func extractBiblioCommonsResults(ctx context.Context) (interface{}, error) {
+	var raw string
+	if err := chromedp.Run(ctx, chromedp.Evaluate(biblioCommonsExtractJS, &raw)); err != nil {
+		return nil, err
+	}
+
+	var items []interface{}
+	if err := json.Unmarshal([]byte(raw), &items); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"Items": items}, nil
+}
@@ -0,0 +1,28 @@
+package main
+
+// Book is the canonical, deduplicated representation of a single work,
+// merged from potentially many per-library hits returned by different
+// backends/domains. Both the JSON (/api/search) and HTML (/search)
+// surfaces render this shape.
+type Book struct {
+	Title    string
+	Authors  []string
+	ISBNs    []string
+	Formats  []string
+	CoverURL string
+
+	// Sources lists every library this Book was seen at, so the UI can
+	// show "available now at LAPL, 3-week wait at ERL" in one row instead
+	// of N duplicated rows.
+	Sources []LibraryAvailability
+}
+
+// LibraryAvailability is a single library's holding of a Book.
+type LibraryAvailability struct {
+	Backend         string
+	Library         string
+	Available       bool
+	CopiesOwned     int
+	CopiesAvailable int
+	Holds           int
+}
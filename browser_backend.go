@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/chromedp/cdproto/emulation"
+	"github.com/chromedp/chromedp"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/shaunakg/libsearch-go/useragent"
+)
+
+// BrowserBackend drives a headless Chrome instance (via chromedp) to
+// search library catalogs whose results only appear in the DOM after
+// JavaScript runs, where a plain HTTP GET (as OverdriveBackend and
+// CloudLibraryBackend do) would only see an empty shell page.
+//
+// Adding a new JS-rendered service is then just constructing one of
+// these with the right template/selector/extract func and registering
+// it from an init(), same as every other backend.
+type BrowserBackend struct {
+	BackendName    string
+	BackendDomains []string
+	URLTemplate    string // formatted with the domain, then has ?query=... appended
+	WaitSelector   string // CSS selector chromedp waits for before scraping
+	Extract        func(ctx context.Context) (interface{}, error)
+}
+
+func (b *BrowserBackend) Name() string      { return b.BackendName }
+func (b *BrowserBackend) Domains() []string { return b.BackendDomains }
+
+func (b *BrowserBackend) Search(ctx context.Context, query string, domain string, page int) (*Result, error) {
+	startTime := time.Now()
+
+	reqURL := fmt.Sprintf(b.URLTemplate, domain) + "?query=" + url.QueryEscape(query) + "&page=" + strconv.Itoa(page)
+
+	browserCtx, cancel := chromedp.NewContext(ctx)
+	defer cancel()
+
+	var data interface{}
+	err := chromedp.Run(browserCtx,
+		emulation.SetUserAgentOverride(useragent.Random()),
+		chromedp.Navigate(reqURL),
+		chromedp.WaitVisible(b.WaitSelector, chromedp.ByQuery),
+		chromedp.ActionFunc(func(c context.Context) error {
+			extracted, err := b.Extract(c)
+			data = extracted
+			return err
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithFields(log.Fields{
+		"backend":  b.BackendName,
+		"domain":   domain,
+		"duration": time.Since(startTime),
+	}).Info("Chromedp request completed")
+
+	return &Result{Library: domain, Data: data}, nil
+}
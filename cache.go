@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"golang.org/x/sync/singleflight"
+)
+
+func init() {
+	// Result.Data holds whatever encoding/json decoded the upstream
+	// payload into, so register the concrete types that can end up
+	// behind that interface before we ever try to gob-encode one.
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
+
+// CacheTTL controls how long a cached backend result stays fresh before
+// it's treated as a miss again.
+var CacheTTL = envDuration("LIBSEARCH_CACHE_TTL", 10*time.Minute)
+
+// cachePath is where the cache is persisted so restarts don't cold-start
+// every query.
+var cachePath = envString("LIBSEARCH_CACHE_PATH", "libsearch-cache.gob")
+
+// persistInterval controls how often a dirty cache gets flushed to disk.
+// Writes are batched on this interval rather than done inline on every
+// cache miss, so a burst of concurrent queries doesn't turn into a full
+// gob-encode-and-rewrite of the whole cache per request.
+var persistInterval = envDuration("LIBSEARCH_CACHE_PERSIST_INTERVAL", 5*time.Second)
+
+type cacheEntry struct {
+	Result *Result
+	Expiry time.Time
+}
+
+// resultCache caches Backend.Search results keyed by
+// (backend, domain, normalized query, page) and uses singleflight so a
+// burst of requests for the same query only hits the upstream once.
+type resultCache struct {
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+	group   singleflight.Group
+	dirty   bool
+}
+
+var cache = newResultCache()
+
+func newResultCache() *resultCache {
+	c := &resultCache{entries: map[string]cacheEntry{}}
+	c.load()
+	go c.persistLoop()
+	return c
+}
+
+// persistLoop flushes the cache to disk on persistInterval, but only
+// when something has changed since the last flush.
+func (c *resultCache) persistLoop() {
+	ticker := time.NewTicker(persistInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.mu.Lock()
+		dirty := c.dirty
+		c.dirty = false
+		c.mu.Unlock()
+
+		if dirty {
+			c.writeToDisk()
+		}
+	}
+}
+
+// cacheKey identifies a single backend/domain/query/page combination.
+func cacheKey(backend, domain, query string, page int) string {
+	return fmt.Sprintf("%s|%s|%s|%d", backend, domain, normalizeQuery(query), page)
+}
+
+// normalizeQuery folds whitespace and case so trivially different
+// queries share a cache entry.
+func normalizeQuery(query string) string {
+	return strings.ToLower(strings.Join(strings.Fields(query), " "))
+}
+
+// search returns the cached result for key if it's fresh, unless skip is
+// set (?nocache=1). On a miss, it calls fn, caching and returning the
+// result; concurrent calls for the same key collapse into one fn call.
+//
+// fn is given its own context bounded by timeout and rooted in
+// context.Background(), not any individual caller's context. Without
+// that, the singleflight "leader" - whichever caller happens to trigger
+// the miss - would have its own request context threaded into the
+// shared upstream call, so every other caller deduped onto the same key
+// would incorrectly inherit the leader's cancellation even though their
+// own deadlines haven't fired.
+func (c *resultCache) search(key string, skip bool, timeout time.Duration, fn func(ctx context.Context) (*Result, error)) (*Result, error) {
+	if !skip {
+		if result, ok := c.get(key); ok {
+			return result, nil
+		}
+	}
+
+	v, err, _ := c.group.Do(key, func() (interface{}, error) {
+		upstreamCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		result, err := fn(upstreamCtx)
+		if err == nil {
+			c.set(key, result)
+		}
+		return result, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, _ := v.(*Result)
+	return result, nil
+}
+
+func (c *resultCache) get(key string) (*Result, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.Expiry) {
+		return nil, false
+	}
+	return entry.Result, true
+}
+
+func (c *resultCache) set(key string, result *Result) {
+	c.mu.Lock()
+	c.entries[key] = cacheEntry{Result: result, Expiry: time.Now().Add(CacheTTL)}
+	c.dirty = true
+	c.mu.Unlock()
+}
+
+// evict removes a single key, reporting whether it was present.
+func (c *resultCache) evict(key string) bool {
+	c.mu.Lock()
+	_, ok := c.entries[key]
+	delete(c.entries, key)
+	if ok {
+		c.dirty = true
+	}
+	c.mu.Unlock()
+
+	return ok
+}
+
+// clear empties the cache entirely.
+func (c *resultCache) clear() {
+	c.mu.Lock()
+	c.entries = map[string]cacheEntry{}
+	c.dirty = true
+	c.mu.Unlock()
+}
+
+// snapshot returns a copy of the cache's keys and expiries, for the
+// /admin/cache inspection endpoint.
+func (c *resultCache) snapshot() map[string]time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	out := make(map[string]time.Time, len(c.entries))
+	for k, e := range c.entries {
+		out[k] = e.Expiry
+	}
+	return out
+}
+
+// writeToDisk writes the cache to cachePath as gob so a restart doesn't
+// cold-start every query. Best-effort: a failure here is logged but
+// doesn't affect whatever request made the cache dirty.
+func (c *resultCache) writeToDisk() {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(c.entries); err != nil {
+		log.WithError(err).Warn("Failed to encode cache for persistence")
+		return
+	}
+
+	if err := os.WriteFile(cachePath, buf.Bytes(), 0600); err != nil {
+		log.WithError(err).Warn("Failed to persist cache to disk")
+	}
+}
+
+// load reads a previously persisted cache from cachePath, if one exists.
+func (c *resultCache) load() {
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		return
+	}
+
+	var entries map[string]cacheEntry
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&entries); err != nil {
+		log.WithError(err).Warn("Failed to decode persisted cache")
+		return
+	}
+
+	c.mu.Lock()
+	c.entries = entries
+	c.mu.Unlock()
+}
+
+func envString(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheGetSetExpiry(t *testing.T) {
+	c := &resultCache{entries: map[string]cacheEntry{}}
+
+	key := "k"
+	want := &Result{Library: "x"}
+	c.set(key, want)
+
+	got, ok := c.get(key)
+	if !ok || got != want {
+		t.Fatalf("get after set = %v, %v; want %v, true", got, ok, want)
+	}
+
+	// Force the entry to have already expired.
+	c.mu.Lock()
+	entry := c.entries[key]
+	entry.Expiry = time.Now().Add(-time.Second)
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	if _, ok := c.get(key); ok {
+		t.Error("get returned a hit for an expired entry")
+	}
+}
+
+func TestCacheEvictAndClear(t *testing.T) {
+	c := &resultCache{entries: map[string]cacheEntry{}}
+	c.set("a", &Result{})
+	c.set("b", &Result{})
+
+	if !c.evict("a") {
+		t.Error("evict(a) = false, want true")
+	}
+	if c.evict("a") {
+		t.Error("evict(a) a second time = true, want false")
+	}
+	if _, ok := c.get("b"); !ok {
+		t.Fatal("evict(a) removed unrelated key b")
+	}
+
+	c.clear()
+	if _, ok := c.get("b"); ok {
+		t.Error("get(b) after clear = true, want false")
+	}
+}
+
+func TestCacheSearchDedupesConcurrentMisses(t *testing.T) {
+	c := &resultCache{entries: map[string]cacheEntry{}}
+
+	var calls int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.search("k", false, time.Second, func(ctx context.Context) (*Result, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(10 * time.Millisecond)
+				return &Result{Library: "x"}, nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("fn called %d times, want 1 (singleflight should dedupe concurrent misses)", got)
+	}
+}
+
+func TestCacheSearchSkipsCacheWhenNoCache(t *testing.T) {
+	c := &resultCache{entries: map[string]cacheEntry{}}
+	c.set("k", &Result{Library: "cached"})
+
+	var called bool
+	result, err := c.search("k", true, time.Second, func(ctx context.Context) (*Result, error) {
+		called = true
+		return &Result{Library: "fresh"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Error("fn not called despite skip=true (?nocache=1)")
+	}
+	if result.Library != "fresh" {
+		t.Errorf("result.Library = %q, want %q", result.Library, "fresh")
+	}
+}
+
+func TestCacheSearchDetachesUpstreamFromCallerContext(t *testing.T) {
+	c := &resultCache{entries: map[string]cacheEntry{}}
+
+	callerCtx, cancel := context.WithCancel(context.Background())
+	cancel() // simulate a client that already disconnected
+
+	var sawCancellation bool
+	result, err := c.search("k", false, time.Second, func(ctx context.Context) (*Result, error) {
+		// fn receives its own context, not the caller's, so cancelling
+		// callerCtx above must not affect it.
+		select {
+		case <-ctx.Done():
+			sawCancellation = true
+		default:
+		}
+		_ = callerCtx
+		return &Result{Library: "x"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sawCancellation {
+		t.Error("upstream call saw its context cancelled from an unrelated caller's disconnect")
+	}
+	if result.Library != "x" {
+		t.Errorf("result.Library = %q, want %q", result.Library, "x")
+	}
+}
+
+func TestCacheKeyNormalizesQuery(t *testing.T) {
+	a := cacheKey("Overdrive", "lapl", "  Harry   Potter ", 1)
+	b := cacheKey("Overdrive", "lapl", "harry potter", 1)
+	if a != b {
+		t.Errorf("cacheKey differs for equivalent queries: %q vs %q", a, b)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/shaunakg/libsearch-go/useragent"
+)
+
+func init() {
+	RegisterBackend(&CloudLibraryBackend{})
+}
+
+// CloudLibraryBackend queries the CloudLibrary CatalogSearch JSON
+// endpoint directly; unlike Overdrive, it returns structured JSON rather
+// than HTML with an embedded script blob, so there's no regex scraping
+// involved.
+type CloudLibraryBackend struct{}
+
+func (b *CloudLibraryBackend) Name() string { return "Cloud Library" }
+
+func (b *CloudLibraryBackend) Domains() []string {
+	return []string{"melbourne", "hobsonsbay", "yarra"}
+}
+
+func (b *CloudLibraryBackend) Search(ctx context.Context, query string, domain string, page int) (*Result, error) {
+	startTime := time.Now()
+
+	// CloudLibrary's CatalogSearch is offset-based rather than
+	// page-based, so translate the 1-indexed page into a pageIndex.
+	pageIndex := page - 1
+	if pageIndex < 0 {
+		pageIndex = 0
+	}
+
+	reqURL := fmt.Sprintf("https://ebook.yourcloudlibrary.com/uisvc/%s/Search/CatalogSearch", domain) +
+		"?media=all&src=lib&title=" + url.QueryEscape(query) +
+		"&pageIndex=" + strconv.Itoa(pageIndex) +
+		"&pageSize=" + strconv.Itoa(pageSize)
+
+	log.WithFields(log.Fields{
+		"url": reqURL,
+	}).Info("Making GET request")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", useragent.Random())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+
+	log.Info("Found Cloud Library results")
+
+	log.WithFields(log.Fields{
+		"duration": time.Since(startTime),
+	}).Info("Request completed")
+
+	return &Result{Library: domain, Data: data}, nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// htmlSearch handles GET /search, rendering the same normalized Books
+// that apiSearch returns as JSON.
+func htmlSearch(w http.ResponseWriter, r *http.Request) {
+
+	startTime := time.Now()
+
+	query := r.URL.Query().Get("query")
+	page := parsePage(r)
+	noCache := r.URL.Query().Get("nocache") == "1"
+
+	data := struct {
+		Query    string
+		Page     int
+		Response searchResponse
+	}{
+		Query: query,
+		Page:  page,
+	}
+
+	if query != "" {
+		data.Response = runSearch(r.Context(), query, page, noCache)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := searchTemplate.Execute(w, data); err != nil {
+		log.WithError(err).Error("Failed to render search template")
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	log.WithFields(log.Fields{
+		"duration": time.Since(startTime),
+	}).Info("HTML search completed")
+
+}
@@ -0,0 +1,137 @@
+package main
+
+// bookHit is a single library's view of a single edition, before it's
+// merged with matching hits from other libraries into a canonical Book.
+type bookHit struct {
+	Title    string
+	Author   string
+	ISBN     string
+	CoverURL string
+	Format   string
+
+	LibraryAvailability
+}
+
+// toBookHits normalizes a backend's raw Result into bookHits. Each
+// backend's on-the-wire JSON is shaped differently, so this is
+// necessarily best-effort: fields that can't be found are left at their
+// zero value rather than erroring.
+func toBookHits(backendName string, res *Result) []bookHit {
+	if res == nil {
+		return nil
+	}
+
+	switch backendName {
+	case "Overdrive":
+		return overdriveHits(res)
+	case "Cloud Library", "BiblioCommons":
+		// Both services' Extract/parse steps normalize into the same
+		// {"Items": [...]} envelope, so they share an extraction path.
+		return itemsEnvelopeHits(backendName, res)
+	default:
+		return nil
+	}
+}
+
+func overdriveHits(res *Result) []bookHit {
+	items, ok := res.Data.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	hits := make([]bookHit, 0, len(items))
+	for _, raw := range items {
+		item, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		hit := bookHit{LibraryAvailability: LibraryAvailability{Backend: "Overdrive", Library: res.Library}}
+		if title, ok := item["title"].(string); ok {
+			hit.Title = title
+		}
+		if author, ok := item["firstCreatorName"].(string); ok {
+			hit.Author = author
+		}
+		if isbn, ok := item["isbn"].(string); ok {
+			hit.ISBN = isbn
+		}
+		if format, ok := item["mediaType"].(string); ok {
+			hit.Format = format
+		}
+		if covers, ok := item["covers"].(map[string]interface{}); ok {
+			if thumb, ok := covers["thumbnail"].(map[string]interface{}); ok {
+				if href, ok := thumb["href"].(string); ok {
+					hit.CoverURL = href
+				}
+			}
+		}
+		if available, ok := item["isAvailable"].(bool); ok {
+			hit.Available = available
+		}
+		if owned, ok := item["ownedCopies"].(float64); ok {
+			hit.CopiesOwned = int(owned)
+		}
+		if avail, ok := item["availableCopies"].(float64); ok {
+			hit.CopiesAvailable = int(avail)
+		}
+		if holds, ok := item["holdsCount"].(float64); ok {
+			hit.Holds = int(holds)
+		}
+
+		hits = append(hits, hit)
+	}
+
+	return hits
+}
+
+func itemsEnvelopeHits(backendName string, res *Result) []bookHit {
+	data, ok := res.Data.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	items, ok := data["Items"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	hits := make([]bookHit, 0, len(items))
+	for _, raw := range items {
+		item, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		hit := bookHit{LibraryAvailability: LibraryAvailability{Backend: backendName, Library: res.Library}}
+		if title, ok := item["Title"].(string); ok {
+			hit.Title = title
+		}
+		if author, ok := item["Author"].(string); ok {
+			hit.Author = author
+		}
+		if isbn, ok := item["ISBN"].(string); ok {
+			hit.ISBN = isbn
+		}
+		if format, ok := item["Format"].(string); ok {
+			hit.Format = format
+		}
+		if cover, ok := item["CoverUrl"].(string); ok {
+			hit.CoverURL = cover
+		}
+		if avail, ok := item["AvailableCopies"].(float64); ok {
+			hit.CopiesAvailable = int(avail)
+			hit.Available = avail > 0
+		}
+		if owned, ok := item["OwnedCopies"].(float64); ok {
+			hit.CopiesOwned = int(owned)
+		}
+		if holds, ok := item["HoldsCount"].(float64); ok {
+			hit.Holds = int(holds)
+		}
+
+		hits = append(hits, hit)
+	}
+
+	return hits
+}
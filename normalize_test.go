@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// cloudLibraryCatalogSearchFixture approximates the shape of a real
+// CloudLibrary CatalogSearch response: an "Items" envelope of records
+// keyed by PascalCase field names. itemsEnvelopeHits decodes it straight
+// out of json.Decoder's map[string]interface{}, so this exercises the
+// same type assertions the live backend would hit.
+const cloudLibraryCatalogSearchFixture = `{
+	"TotalItems": 1,
+	"Items": [
+		{
+			"Title": "The Great Gatsby",
+			"Author": "F. Scott Fitzgerald",
+			"ISBN": "9780743273565",
+			"Format": "EPUB",
+			"CoverUrl": "https://ebook.yourcloudlibrary.com/covers/9780743273565.jpg",
+			"OwnedCopies": 3,
+			"AvailableCopies": 1,
+			"HoldsCount": 2
+		}
+	]
+}`
+
+func TestItemsEnvelopeHitsParsesCloudLibraryFixture(t *testing.T) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(cloudLibraryCatalogSearchFixture), &data); err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+
+	res := &Result{Library: "melbourne", Data: data}
+	hits := toBookHits("Cloud Library", res)
+
+	if len(hits) != 1 {
+		t.Fatalf("got %d hits, want 1", len(hits))
+	}
+
+	got := hits[0]
+	want := bookHit{
+		Title:    "The Great Gatsby",
+		Author:   "F. Scott Fitzgerald",
+		ISBN:     "9780743273565",
+		Format:   "EPUB",
+		CoverURL: "https://ebook.yourcloudlibrary.com/covers/9780743273565.jpg",
+		LibraryAvailability: LibraryAvailability{
+			Backend:         "Cloud Library",
+			Library:         "melbourne",
+			Available:       true,
+			CopiesOwned:     3,
+			CopiesAvailable: 1,
+			Holds:           2,
+		},
+	}
+
+	if got != want {
+		t.Errorf("itemsEnvelopeHits parsed fixture as %+v, want %+v", got, want)
+	}
+}
+
+func TestItemsEnvelopeHitsUnknownShapeYieldsNoHits(t *testing.T) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"Results": []}`), &data); err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+
+	hits := toBookHits("Cloud Library", &Result{Library: "melbourne", Data: data})
+	if hits != nil {
+		t.Errorf("got %v, want nil hits when the envelope key is missing", hits)
+	}
+}
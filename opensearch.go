@@ -0,0 +1,23 @@
+package main
+
+import "net/http"
+
+// openSearchDescription advertises Libsearch to browsers as an
+// installable search provider. See
+// https://github.com/dewitt/opensearch for the spec.
+const openSearchDescription = `<?xml version="1.0" encoding="UTF-8"?>
+<OpenSearchDescription xmlns="http://a9.com/-/spec/opensearch/1.1/">
+  <ShortName>Libsearch</ShortName>
+  <Description>Search your local library's ebook and audiobook catalog</Description>
+  <Tags>library books overdrive cloudlibrary</Tags>
+  <Url type="text/html" template="/search?query={searchTerms}&amp;page={startPage?}"/>
+  <Url type="application/json" template="/api/search?query={searchTerms}&amp;page={startPage?}"/>
+  <InputEncoding>UTF-8</InputEncoding>
+</OpenSearchDescription>
+`
+
+// openSearch serves /opensearch.xml.
+func openSearch(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/opensearchdescription+xml")
+	w.Write([]byte(openSearchDescription))
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/shaunakg/libsearch-go/useragent"
+)
+
+func init() {
+	RegisterBackend(&OverdriveBackend{})
+}
+
+// OverdriveBackend queries Overdrive tenant sites by scraping the
+// window.OverDrive.mediaItems blob embedded in the search page HTML.
+type OverdriveBackend struct{}
+
+func (b *OverdriveBackend) Name() string { return "Overdrive" }
+
+func (b *OverdriveBackend) Domains() []string {
+	return []string{"lapl", "erl", "portphillip", "boroondara", "baysidelibrary"}
+}
+
+var overdriveMediaItemsRe = regexp.MustCompile(`window.OverDrive.mediaItems = (.*);`)
+var overdriveTenantRe = regexp.MustCompile(`window.OverDrive.tenant = (.*);`)
+
+// Search makes the HTTP request, parses out the JSON and returns the results.
+func (b *OverdriveBackend) Search(ctx context.Context, query string, domain string, page int) (*Result, error) {
+	startTime := time.Now()
+
+	reqURL := fmt.Sprintf("https://%s.overdrive.com/search", domain) +
+		"?query=" + url.QueryEscape(query) +
+		"&page=" + strconv.Itoa(page)
+
+	log.WithFields(log.Fields{
+		"url": reqURL,
+	}).Info("Making GET request")
+
+	// make the GET request with a browser user agent
+	client := &http.Client{}
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", useragent.Random())
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read the response body
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the HTML response into a string
+	html := string(body)
+
+	// Search the HTML using regex for the JSON content
+	match := overdriveMediaItemsRe.FindStringSubmatch(html)
+
+	// Search the HTML for the library ID
+	matchID := overdriveTenantRe.FindStringSubmatch(html)
+
+	// If there is no match, there were no results
+	if len(match) == 0 {
+		log.Info("No Overdrive results found")
+		return nil, nil
+	}
+
+	log.Info("Found Overdrive results")
+
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(match[1]), &data); err != nil {
+		return nil, err
+	}
+
+	library := ""
+	if len(matchID) > 1 {
+		library = matchID[1]
+	}
+
+	log.WithFields(log.Fields{
+		"duration": time.Since(startTime),
+	}).Info("Request completed")
+
+	return &Result{Library: library, Data: data}, nil
+}
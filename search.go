@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// apiVersion identifies the /api/search response shape so clients can
+// detect breaking changes going forward.
+const apiVersion = "1"
+
+// pageSize is the number of results requested per backend page.
+const pageSize = 20
+
+type backendError struct {
+	Backend string `json:"backend"`
+	Domain  string `json:"domain"`
+	Error   string `json:"error"`
+}
+
+type searchResponse struct {
+	Overdrive     []interface{}  `json:"Overdrive"`
+	CloudLibrary  []interface{}  `json:"CloudLibrary"`
+	BiblioCommons []interface{}  `json:"BiblioCommons"`
+	Books         []Book         `json:"books"`
+	Partial       bool           `json:"partial,omitempty"`
+	Errors        []backendError `json:"errors,omitempty"`
+}
+
+// runSearch fans a query out across every domain of every registered
+// backend for the given page, collecting both the raw per-backend
+// payloads (for API back-compat) and normalized Books (for rendering),
+// while honoring RequestDeadline and BackendTimeout. Results are served
+// from the cache unless noCache is set.
+func runSearch(ctx context.Context, query string, page int, noCache bool) searchResponse {
+	response := searchResponse{
+		Overdrive:     []interface{}{},
+		CloudLibrary:  []interface{}{},
+		BiblioCommons: []interface{}{},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, RequestDeadline)
+	defer cancel()
+
+	var hits []bookHit
+
+	type backendResult struct {
+		backend Backend
+		domain  string
+		result  *Result
+		err     error
+	}
+
+	// total is computed up front so the channel can be sized to hold
+	// every possible send. Without that, a goroutine whose send arrives
+	// after the collect loop below has already stopped reading (client
+	// disconnected, or the request deadline fired) would block forever
+	// trying to write to an unbuffered channel - exactly the leak this
+	// request was meant to close.
+	total := 0
+	for _, b := range Backends() {
+		total += len(b.Domains())
+	}
+	channel := make(chan backendResult, total)
+
+	for _, b := range Backends() {
+		for _, domain := range b.Domains() {
+
+			log.WithFields(log.Fields{
+				"backend": b.Name(),
+				"domain":  domain,
+				"query":   query,
+				"page":    page,
+			}).Info("Searching backend")
+
+			go func(b Backend, domain string) {
+				key := cacheKey(b.Name(), domain, query, page)
+				result, err := cache.search(key, noCache, BackendTimeout, func(backendCtx context.Context) (*Result, error) {
+					return b.Search(backendCtx, query, domain, page)
+				})
+				channel <- backendResult{backend: b, domain: domain, result: result, err: err}
+			}(b, domain)
+
+		}
+	}
+
+	// Get all the results from the channel, but stop waiting as soon as
+	// the request deadline fires so one stuck domain can't hang the
+	// whole response.
+collect:
+	for i := 0; i < total; i++ {
+
+		select {
+		case res := <-channel:
+
+			if res.err != nil {
+				log.WithFields(log.Fields{
+					"backend": res.backend.Name(),
+					"domain":  res.domain,
+					"error":   res.err,
+				}).Error("Backend search failed")
+				response.Errors = append(response.Errors, backendError{
+					Backend: res.backend.Name(),
+					Domain:  res.domain,
+					Error:   res.err.Error(),
+				})
+				continue
+			}
+
+			// If the result is nil, skip it
+			if res.result == nil {
+				continue
+			}
+
+			// Append the raw results to the response, keyed by backend
+			switch res.backend.Name() {
+			case "Overdrive":
+				response.Overdrive = append(response.Overdrive, res.result)
+			case "Cloud Library":
+				response.CloudLibrary = append(response.CloudLibrary, res.result)
+			case "BiblioCommons":
+				response.BiblioCommons = append(response.BiblioCommons, res.result)
+			}
+
+			hits = append(hits, toBookHits(res.backend.Name(), res.result)...)
+
+		case <-ctx.Done():
+			log.Warn("Search deadline exceeded, returning partial results")
+			response.Partial = true
+			break collect
+		}
+
+	}
+
+	response.Books = aggregateBooks(hits)
+
+	return response
+}
+
+// parsePage reads the ?page= query parameter, defaulting to page 1 for
+// anything missing or invalid.
+func parsePage(r *http.Request) int {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		return 1
+	}
+	return page
+}
+
+// apiSearch handles GET /api/search?query=...&page=N and returns JSON.
+func apiSearch(w http.ResponseWriter, r *http.Request) {
+
+	startTime := time.Now()
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("X-Libsearch-Api-Version", apiVersion)
+
+	// Get the 'search' query parameter
+	query := r.URL.Query().Get("query")
+
+	// Reject request if the query param is not found or if the length is zero
+	if query == "" || len(query) == 0 {
+		log.Error("Query parameter not found")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	page := parsePage(r)
+	noCache := r.URL.Query().Get("nocache") == "1"
+
+	log.WithFields(log.Fields{
+		"query": query,
+		"page":  page,
+	}).Info("Search query received")
+
+	response := runSearch(r.Context(), query, page, noCache)
+
+	log.Info("Returning searchResponse")
+
+	// Send the JSON to the client
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+
+	log.WithFields(log.Fields{
+		"duration": time.Since(startTime),
+	}).Info("Search completed")
+
+}
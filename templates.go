@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+)
+
+// templateFuncs are the helpers available to templates/*.html.
+var templateFuncs = template.FuncMap{
+	"add":      func(a, b int) int { return a + b },
+	"sub":      func(a, b int) int { return a - b },
+	"waitTime": humanWait,
+}
+
+var searchTemplate = template.Must(
+	template.New("search.html").Funcs(templateFuncs).ParseFiles("templates/search.html"),
+)
+
+// humanWait turns a hold count into a rough human-readable wait estimate,
+// assuming a new copy becomes available roughly every three weeks.
+func humanWait(holds int) string {
+	if holds <= 0 {
+		return "Available now"
+	}
+
+	weeks := holds * 3
+	if weeks < 8 {
+		return fmt.Sprintf("%d week wait", weeks)
+	}
+
+	return fmt.Sprintf("~%d month wait", weeks/4)
+}
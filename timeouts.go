@@ -0,0 +1,26 @@
+package main
+
+import (
+	"os"
+	"time"
+)
+
+// BackendTimeout bounds how long a single backend/domain search may run
+// before its context is cancelled, so one slow host can't block the rest
+// of the fan-out indefinitely. RequestDeadline bounds the whole search
+// handler, after which any still-outstanding backends are abandoned and
+// the response is returned as partial. Both are overridable by
+// environment variable so a deployment can tune them without a rebuild.
+var (
+	BackendTimeout  = envDuration("LIBSEARCH_BACKEND_TIMEOUT", 10*time.Second)
+	RequestDeadline = envDuration("LIBSEARCH_REQUEST_DEADLINE", 20*time.Second)
+)
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
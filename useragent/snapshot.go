@@ -0,0 +1,19 @@
+package useragent
+
+import "errors"
+
+var errNoUsableAgents = errors.New("useragent: caniuse feed contained no usable Firefox/Chrome entries")
+
+// snapshot is a bundled fallback dataset used when the caniuse feed can't
+// be reached (offline sandbox, network outage, feed schema change). The
+// weights approximate relative global usage share at the time this was
+// last updated and are intentionally coarse - they only need to avoid
+// sending every request with an identical, increasingly stale UA.
+var snapshot = []entry{
+	{ua: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36", usage: 32},
+	{ua: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/125.0.0.0 Safari/537.36", usage: 18},
+	{ua: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36", usage: 14},
+	{ua: "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:127.0) Gecko/20100101 Firefox/127.0", usage: 10},
+	{ua: "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:126.0) Gecko/20100101 Firefox/126.0", usage: 7},
+	{ua: "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36", usage: 5},
+}
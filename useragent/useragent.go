@@ -0,0 +1,135 @@
+// Package useragent provides a pool of realistic, live-refreshed browser
+// User-Agent strings so outbound scraper requests don't all present the
+// same stale signature.
+package useragent
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// caniuseFulldataURL is the caniuse "fulldata" feed: it lists, per
+// browser, every known version alongside its global usage share.
+const caniuseFulldataURL = "https://raw.githubusercontent.com/Fyrd/caniuse/main/fulldata-json/data-2.0.json"
+
+// refreshInterval controls how often the dataset is re-fetched from
+// caniuse; in between, Random samples from the cached copy.
+const refreshInterval = 24 * time.Hour
+
+// entry is a single browser build, weighted by its real-world usage
+// share so Random samples versions roughly in proportion to live traffic.
+type entry struct {
+	ua    string
+	usage float64
+}
+
+var (
+	mu      sync.Mutex
+	dataset []entry
+	expiry  time.Time
+)
+
+// Random returns a User-Agent string sampled from current Firefox and
+// Chromium versions, weighted by global usage share. The dataset is
+// refreshed from caniuse at most once per refreshInterval; if the fetch
+// fails and nothing has been cached yet, it falls back to a bundled
+// snapshot.
+func Random() string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if time.Now().After(expiry) {
+		if fresh, err := fetch(); err == nil {
+			dataset = fresh
+			expiry = time.Now().Add(refreshInterval)
+		} else if dataset == nil {
+			dataset = snapshot
+			expiry = time.Now().Add(refreshInterval)
+		}
+	}
+
+	return sample(dataset)
+}
+
+// sample picks a weighted-random entry from entries.
+func sample(entries []entry) string {
+	if len(entries) == 0 {
+		return snapshot[0].ua
+	}
+
+	total := 0.0
+	for _, e := range entries {
+		total += e.usage
+	}
+
+	r := rand.Float64() * total
+	for _, e := range entries {
+		r -= e.usage
+		if r <= 0 {
+			return e.ua
+		}
+	}
+
+	return entries[len(entries)-1].ua
+}
+
+// caniuseFeed mirrors the subset of the fulldata JSON schema we care
+// about: per-browser version usage shares.
+type caniuseFeed struct {
+	Agents map[string]struct {
+		UsageShare map[string]float64 `json:"usage_global"`
+	} `json:"agents"`
+}
+
+// fetch pulls and parses the current caniuse fulldata feed into a
+// weighted entry list covering Firefox and Chrome/Chromium versions.
+func fetch() ([]entry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", caniuseFulldataURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var feed caniuseFeed
+	if err := json.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, err
+	}
+
+	var out []entry
+	for id, agent := range feed.Agents {
+		if id != "firefox" && id != "chrome" {
+			continue
+		}
+		for version, usage := range agent.UsageShare {
+			if usage <= 0 {
+				continue
+			}
+			out = append(out, entry{ua: buildUA(id, version), usage: usage})
+		}
+	}
+
+	if len(out) == 0 {
+		return nil, errNoUsableAgents
+	}
+
+	return out, nil
+}
+
+func buildUA(browser, version string) string {
+	if browser == "firefox" {
+		return "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:" + version + ") Gecko/20100101 Firefox/" + version
+	}
+	return "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/" + version + " Safari/537.36"
+}
@@ -0,0 +1,51 @@
+package useragent
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSampleAlwaysReturnsAnEntry(t *testing.T) {
+	entries := []entry{
+		{ua: "a", usage: 1},
+		{ua: "b", usage: 2},
+	}
+
+	for i := 0; i < 100; i++ {
+		got := sample(entries)
+		if got != "a" && got != "b" {
+			t.Fatalf("sample returned unexpected UA %q", got)
+		}
+	}
+}
+
+func TestSampleEmptyFallsBackToSnapshot(t *testing.T) {
+	if got := sample(nil); got != snapshot[0].ua {
+		t.Errorf("sample(nil) = %q, want snapshot[0].ua %q", got, snapshot[0].ua)
+	}
+}
+
+func TestSampleWeighting(t *testing.T) {
+	entries := []entry{
+		{ua: "common", usage: 90},
+		{ua: "rare", usage: 10},
+	}
+
+	const trials = 20000
+	counts := map[string]int{}
+	for i := 0; i < trials; i++ {
+		counts[sample(entries)]++
+	}
+
+	gotShare := float64(counts["common"]) / float64(trials)
+	wantShare := 0.9
+	if math.Abs(gotShare-wantShare) > 0.05 {
+		t.Errorf("sampled %q %.2f%% of the time, want close to %.0f%%", "common", gotShare*100, wantShare*100)
+	}
+}
+
+func TestRandomNeverEmpty(t *testing.T) {
+	if ua := Random(); ua == "" {
+		t.Error("Random() returned an empty string")
+	}
+}